@@ -0,0 +1,50 @@
+// Command keyrotate generates a new JWT signing key for an app and makes
+// it the active one: Auth.Login starts signing with it immediately, while
+// tokens already issued keep verifying against the key they were actually
+// signed with until it falls outside its validity window.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"auth_grpc/internal/lib/jwt"
+	"auth_grpc/internal/storage/sqlite"
+)
+
+func main() {
+	var (
+		storagePath = flag.String("storage-path", "", "path to the SQLite storage file")
+		appID       = flag.Int("app-id", 0, "app to rotate the signing key for")
+		algorithm   = flag.String("algorithm", jwt.AlgorithmRS256, "signing algorithm: RS256 or EdDSA")
+		validFor    = flag.Duration("valid-for", 90*24*time.Hour, "how long the new key stays valid for verification")
+	)
+	flag.Parse()
+
+	if *storagePath == "" || *appID == 0 {
+		log.Fatal("storage-path and app-id are required")
+	}
+
+	storage, err := sqlite.New(*storagePath)
+	if err != nil {
+		log.Fatalf("failed to open storage: %v", err)
+	}
+
+	key, err := jwt.GenerateKeyPair(*algorithm)
+	if err != nil {
+		log.Fatalf("failed to generate key pair: %v", err)
+	}
+
+	key.NotBefore = time.Now()
+	key.NotAfter = key.NotBefore.Add(*validFor)
+
+	if err := storage.SaveSigningKey(context.Background(), *appID, key); err != nil {
+		log.Fatalf("failed to save signing key: %v", err)
+	}
+
+	fmt.Printf("rotated signing key for app %d: kid=%s algorithm=%s valid until %s\n",
+		*appID, key.Kid, key.Algorithm, key.NotAfter.Format(time.RFC3339))
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type App struct {
+	ID   int
+	Name string
+
+	// LoginMaxAttempts, LoginWindow and LoginLockoutFor tune this app's own
+	// brute-force lockout policy. LoginMaxAttempts <= 0 disables lockout
+	// for the app entirely.
+	LoginMaxAttempts int
+	LoginWindow      time.Duration
+	LoginLockoutFor  time.Duration
+
+	// RequireVerifiedEmail rejects Login to this app for users who haven't
+	// yet verified their email address. Verification is per user, not per app.
+	RequireVerifiedEmail bool
+}
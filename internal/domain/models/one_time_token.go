@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	OneTimeTokenPurposeReset  = "reset"
+	OneTimeTokenPurposeVerify = "verify"
+)
+
+// OneTimeToken is a single-use token handed to a user out-of-band (by
+// email) to prove control of their account, for password reset or email
+// verification. Only the hash of the token value is ever persisted.
+type OneTimeToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	Purpose   string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
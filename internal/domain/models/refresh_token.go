@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RefreshToken is a single refresh token issued to a user for a given app.
+// Only the hash of the token value is ever persisted; the raw token is
+// handed to the client once and never stored.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	AppID     int
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
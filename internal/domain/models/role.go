@@ -0,0 +1,9 @@
+package models
+
+// Role is a named set of permissions, scoped to a single app so the same
+// role name (e.g. "admin") can mean different things in different apps.
+type Role struct {
+	ID    int64
+	AppID int
+	Name  string
+}
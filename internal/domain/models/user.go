@@ -0,0 +1,9 @@
+package models
+
+type User struct {
+	ID            int64
+	Email         string
+	PassHash      []byte
+	PassHashAlgo  string
+	EmailVerified bool
+}
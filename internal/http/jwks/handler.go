@@ -0,0 +1,97 @@
+// Package jwks exposes the public half of each app's signing keys as a
+// JWKS document, so other services can verify tokens issued by Auth
+// without sharing a secret.
+package jwks
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"auth_grpc/internal/lib/jwt"
+)
+
+type key struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type keySet struct {
+	Keys []key `json:"keys"`
+}
+
+// Handler serves GET /.well-known/jwks.json?app_id=... with the set of
+// keys currently valid for verifying tokens issued for that app.
+type Handler struct {
+	keys jwt.KeyProvider
+}
+
+func NewHandler(keys jwt.KeyProvider) *Handler {
+	return &Handler{keys: keys}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	appID, err := strconv.Atoi(r.URL.Query().Get("app_id"))
+	if err != nil {
+		http.Error(w, "app_id is required", http.StatusBadRequest)
+		return
+	}
+
+	verificationKeys, err := h.keys.VerificationKeys(r.Context(), appID)
+	if err != nil {
+		http.Error(w, "failed to load signing keys", http.StatusInternalServerError)
+		return
+	}
+
+	set := keySet{Keys: make([]key, 0, len(verificationKeys))}
+	for _, vk := range verificationKeys {
+		encoded, err := encodeKey(vk)
+		if err != nil {
+			http.Error(w, "failed to encode signing key", http.StatusInternalServerError)
+			return
+		}
+
+		set.Keys = append(set.Keys, encoded)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+func encodeKey(vk jwt.VerificationKey) (key, error) {
+	switch pub := vk.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key{
+			Kty: "RSA",
+			Use: "sig",
+			Kid: vk.Kid,
+			Alg: vk.Algorithm,
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+
+	case ed25519.PublicKey:
+		return key{
+			Kty: "OKP",
+			Use: "sig",
+			Kid: vk.Kid,
+			Alg: vk.Algorithm,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}, nil
+
+	default:
+		return key{}, fmt.Errorf("jwks: unsupported public key type %T", pub)
+	}
+}
@@ -0,0 +1,60 @@
+package hash
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const AlgorithmBcrypt = "bcrypt"
+
+// BcryptHasher hashes passwords with bcrypt. Kept around mainly so
+// existing hashes issued before Argon2id/scrypt support can still be
+// verified and transparently rehashed.
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	raw, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func (h *BcryptHasher) Compare(encodedHash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password)); err != nil {
+		return ErrMismatchedHashAndPassword
+	}
+
+	return nil
+}
+
+func (h *BcryptHasher) Algorithm() string {
+	return AlgorithmBcrypt
+}
+
+func (h *BcryptHasher) Owns(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+func (h *BcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	if err != nil {
+		return true
+	}
+
+	return cost < h.cost
+}
@@ -0,0 +1,92 @@
+package hash
+
+import "errors"
+
+var (
+	ErrMismatchedHashAndPassword = errors.New("hash: password does not match hash")
+	ErrInvalidHashFormat         = errors.New("hash: invalid encoded hash format")
+)
+
+// Hasher hashes and verifies passwords for a single algorithm. Encoded
+// hashes produced by a Hasher are self-describing (algorithm, params, salt),
+// the same way bcrypt, argon2id and scrypt format their own output, so a
+// given hash can always be verified and inspected without consulting any
+// external state.
+type Hasher interface {
+	// Hash returns a new encoded hash for password, safe to store as-is.
+	Hash(password string) (string, error)
+	// Compare reports whether password matches encodedHash.
+	Compare(encodedHash, password string) error
+	// Algorithm is the identifier stored alongside the hash so that Login
+	// knows which Hasher produced it.
+	Algorithm() string
+	// Owns reports whether encodedHash looks like it was produced by this Hasher.
+	Owns(encodedHash string) bool
+	// NeedsRehash reports whether encodedHash was produced with weaker
+	// parameters than this Hasher's current policy.
+	NeedsRehash(encodedHash string) bool
+}
+
+// Registry dispatches Compare/NeedsRehash to whichever registered Hasher
+// produced a given encoded hash, while always hashing new passwords with
+// the active one. It implements Hasher itself, so it can be injected
+// anywhere a single Hasher is expected.
+type Registry struct {
+	active Hasher
+	all    []Hasher
+}
+
+// NewRegistry builds a Registry that hashes new passwords with active, but
+// can still verify (and flag for rehashing) hashes produced by any of the
+// legacy algorithms.
+func NewRegistry(active Hasher, legacy ...Hasher) *Registry {
+	return &Registry{
+		active: active,
+		all:    append([]Hasher{active}, legacy...),
+	}
+}
+
+func (r *Registry) Hash(password string) (string, error) {
+	return r.active.Hash(password)
+}
+
+func (r *Registry) Compare(encodedHash, password string) error {
+	h, err := r.find(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	return h.Compare(encodedHash, password)
+}
+
+func (r *Registry) Algorithm() string {
+	return r.active.Algorithm()
+}
+
+func (r *Registry) Owns(encodedHash string) bool {
+	_, err := r.find(encodedHash)
+	return err == nil
+}
+
+func (r *Registry) NeedsRehash(encodedHash string) bool {
+	h, err := r.find(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	if h.Algorithm() != r.active.Algorithm() {
+		return true
+	}
+
+	return h.NeedsRehash(encodedHash)
+}
+
+func (r *Registry) find(encodedHash string) (Hasher, error) {
+	for _, h := range r.all {
+		if h.Owns(encodedHash) {
+			return h, nil
+		}
+	}
+
+	return nil, ErrInvalidHashFormat
+}
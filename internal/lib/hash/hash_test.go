@@ -0,0 +1,97 @@
+package hash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHashers_RoundTrip(t *testing.T) {
+	hashers := map[string]Hasher{
+		AlgorithmArgon2id: NewArgon2idHasher(DefaultArgon2idParams()),
+		AlgorithmScrypt:   NewScryptHasher(DefaultScryptParams()),
+		AlgorithmBcrypt:   NewBcryptHasher(bcryptTestCost),
+	}
+
+	for name, h := range hashers {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := h.Hash("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+
+			if !h.Owns(encoded) {
+				t.Fatalf("Owns() = false for a hash %s just produced", name)
+			}
+
+			if err := h.Compare(encoded, "correct horse battery staple"); err != nil {
+				t.Fatalf("Compare() with correct password error = %v", err)
+			}
+
+			if err := h.Compare(encoded, "wrong password"); !errors.Is(err, ErrMismatchedHashAndPassword) {
+				t.Fatalf("Compare() with wrong password error = %v, want %v", err, ErrMismatchedHashAndPassword)
+			}
+
+			if h.NeedsRehash(encoded) {
+				t.Fatalf("NeedsRehash() = true for a hash just produced with the current params")
+			}
+		})
+	}
+}
+
+// bcryptTestCost keeps the bcrypt test fast; production uses bcrypt.DefaultCost.
+const bcryptTestCost = 4
+
+func TestArgon2idHasher_NeedsRehash_WhenParamsWeaken(t *testing.T) {
+	weak := NewArgon2idHasher(Argon2idParams{Memory: 16 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+
+	encoded, err := weak.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	strong := NewArgon2idHasher(DefaultArgon2idParams())
+	if !strong.NeedsRehash(encoded) {
+		t.Fatal("NeedsRehash() = false for a hash produced with weaker params, want true")
+	}
+}
+
+func TestRegistry_DispatchesToOwningHasher(t *testing.T) {
+	argon2 := NewArgon2idHasher(DefaultArgon2idParams())
+	bcrypt := NewBcryptHasher(bcryptTestCost)
+
+	legacyHash, err := bcrypt.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	r := NewRegistry(argon2, bcrypt)
+
+	if err := r.Compare(legacyHash, "password"); err != nil {
+		t.Fatalf("Registry.Compare() on legacy bcrypt hash error = %v", err)
+	}
+
+	if r.Algorithm() != AlgorithmArgon2id {
+		t.Fatalf("Registry.Algorithm() = %q, want %q (the active hasher)", r.Algorithm(), AlgorithmArgon2id)
+	}
+
+	if !r.NeedsRehash(legacyHash) {
+		t.Fatal("Registry.NeedsRehash() = false for a hash from a non-active algorithm, want true")
+	}
+
+	newHash, err := r.Hash("password")
+	if err != nil {
+		t.Fatalf("Registry.Hash() error = %v", err)
+	}
+
+	if r.NeedsRehash(newHash) {
+		t.Fatal("Registry.NeedsRehash() = true for a hash just produced by the active hasher")
+	}
+}
+
+func TestRegistry_Compare_UnknownFormat(t *testing.T) {
+	r := NewRegistry(NewArgon2idHasher(DefaultArgon2idParams()))
+
+	if err := r.Compare("not-a-real-hash", "password"); !errors.Is(err, ErrInvalidHashFormat) {
+		t.Fatalf("Compare() error = %v, want %v", err, ErrInvalidHashFormat)
+	}
+}
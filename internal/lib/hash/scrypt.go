@@ -0,0 +1,125 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const AlgorithmScrypt = "scrypt"
+
+// ScryptParams controls the cost of the scrypt KDF. N must be a power of two.
+type ScryptParams struct {
+	N          int
+	R          int
+	P          int
+	SaltLength int
+	KeyLength  int
+}
+
+func DefaultScryptParams() ScryptParams {
+	return ScryptParams{
+		N:          1 << 15,
+		R:          8,
+		P:          1,
+		SaltLength: 16,
+		KeyLength:  32,
+	}
+}
+
+// ScryptHasher hashes passwords with scrypt, encoding params and salt into
+// the stored hash as $scrypt$ln=..,r=..,p=..$salt$hash (ln is log2(N)).
+type ScryptHasher struct {
+	params ScryptParams
+}
+
+func NewScryptHasher(params ScryptParams) *ScryptHasher {
+	return &ScryptHasher{params: params}
+}
+
+func (h *ScryptHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, h.params.N, h.params.R, h.params.P, h.params.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		int(math.Log2(float64(h.params.N))), h.params.R, h.params.P,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *ScryptHasher) Compare(encodedHash, password string) error {
+	params, salt, key, err := decodeScryptHash(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	otherKey, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, len(key))
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+
+	return nil
+}
+
+func (h *ScryptHasher) Algorithm() string {
+	return AlgorithmScrypt
+}
+
+func (h *ScryptHasher) Owns(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$scrypt$")
+}
+
+func (h *ScryptHasher) NeedsRehash(encodedHash string) bool {
+	params, _, _, err := decodeScryptHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params.N < h.params.N || params.R < h.params.R || params.P < h.params.P
+}
+
+func decodeScryptHash(encodedHash string) (ScryptParams, []byte, []byte, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 {
+		return ScryptParams{}, nil, nil, ErrInvalidHashFormat
+	}
+
+	var ln int
+	var params ScryptParams
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &ln, &params.R, &params.P); err != nil {
+		return ScryptParams{}, nil, nil, ErrInvalidHashFormat
+	}
+	params.N = 1 << ln
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return ScryptParams{}, nil, nil, ErrInvalidHashFormat
+	}
+	params.SaltLength = len(salt)
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return ScryptParams{}, nil, nil, ErrInvalidHashFormat
+	}
+	params.KeyLength = len(key)
+
+	return params, salt, key, nil
+}
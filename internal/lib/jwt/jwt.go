@@ -0,0 +1,65 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"auth_grpc/internal/domain/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims carries the authorization data resolved for a user/app pair
+// (e.g. by permissions.AuthZ) that NewToken embeds alongside the base
+// identity claims, so downstream services can enforce authorization
+// without a round trip back to Auth.
+type Claims struct {
+	Roles       []string
+	Permissions []string
+}
+
+// NewToken creates a new JWT access token for the given user and app,
+// signed with app's current signing key from keys.
+func NewToken(ctx context.Context, user models.User, app models.App, duration time.Duration, keys KeyProvider, claims Claims) (string, error) {
+	signingKey, err := keys.ActiveSigningKey(ctx, app.ID)
+	if err != nil {
+		return "", err
+	}
+
+	method, err := signingMethod(signingKey.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	mapClaims := jwt.MapClaims{
+		"uid":    user.ID,
+		"email":  user.Email,
+		"app_id": app.ID,
+		"exp":    time.Now().Add(duration).Unix(),
+	}
+
+	if len(claims.Roles) > 0 {
+		mapClaims["roles"] = claims.Roles
+	}
+
+	if len(claims.Permissions) > 0 {
+		mapClaims["permissions"] = claims.Permissions
+	}
+
+	token := jwt.NewWithClaims(method, mapClaims)
+	token.Header["kid"] = signingKey.Kid
+
+	return token.SignedString(signingKey.PrivateKey)
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("jwt: unsupported signing algorithm %q", algorithm)
+	}
+}
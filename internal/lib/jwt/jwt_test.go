@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auth_grpc/internal/domain/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeKeyProvider signs with a single freshly generated key pair for every app.
+type fakeKeyProvider struct {
+	signing SigningKey
+	verify  VerificationKey
+}
+
+func newFakeKeyProvider(t *testing.T, algorithm string) *fakeKeyProvider {
+	t.Helper()
+
+	stored, err := GenerateKeyPair(algorithm)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair(%q) error = %v", algorithm, err)
+	}
+
+	signer, err := decodePrivateKeyPEM(stored.PrivatePEM)
+	if err != nil {
+		t.Fatalf("decodePrivateKeyPEM() error = %v", err)
+	}
+
+	pub, err := decodePublicKeyPEM(stored.PublicPEM)
+	if err != nil {
+		t.Fatalf("decodePublicKeyPEM() error = %v", err)
+	}
+
+	return &fakeKeyProvider{
+		signing: SigningKey{Kid: stored.Kid, Algorithm: stored.Algorithm, PrivateKey: signer},
+		verify:  VerificationKey{Kid: stored.Kid, Algorithm: stored.Algorithm, PublicKey: pub},
+	}
+}
+
+func (p *fakeKeyProvider) ActiveSigningKey(context.Context, int) (SigningKey, error) {
+	return p.signing, nil
+}
+
+func (p *fakeKeyProvider) VerificationKeys(context.Context, int) ([]VerificationKey, error) {
+	return []VerificationKey{p.verify}, nil
+}
+
+func TestNewToken_SignsAndVerifies(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			keys := newFakeKeyProvider(t, algorithm)
+			user := models.User{ID: 7, Email: "user@example.com"}
+			app := models.App{ID: 1, Name: "test-app"}
+
+			raw, err := NewToken(context.Background(), user, app, time.Hour, keys, Claims{
+				Roles:       []string{"admin"},
+				Permissions: []string{"users:read"},
+			})
+			if err != nil {
+				t.Fatalf("NewToken() error = %v", err)
+			}
+
+			parsed, err := jwt.Parse(raw, func(token *jwt.Token) (interface{}, error) {
+				if token.Header["kid"] != keys.verify.Kid {
+					t.Fatalf("token kid = %v, want %v", token.Header["kid"], keys.verify.Kid)
+				}
+				return keys.verify.PublicKey, nil
+			})
+			if err != nil {
+				t.Fatalf("jwt.Parse() error = %v", err)
+			}
+			if !parsed.Valid {
+				t.Fatal("parsed token is not valid")
+			}
+
+			claims, ok := parsed.Claims.(jwt.MapClaims)
+			if !ok {
+				t.Fatalf("claims type = %T, want jwt.MapClaims", parsed.Claims)
+			}
+
+			if got := claims["email"]; got != user.Email {
+				t.Errorf("email claim = %v, want %v", got, user.Email)
+			}
+
+			roles, ok := claims["roles"].([]interface{})
+			if !ok || len(roles) != 1 || roles[0] != "admin" {
+				t.Errorf("roles claim = %v, want [admin]", claims["roles"])
+			}
+		})
+	}
+}
+
+func TestNewToken_OmitsEmptyClaims(t *testing.T) {
+	keys := newFakeKeyProvider(t, AlgorithmEdDSA)
+	user := models.User{ID: 1, Email: "user@example.com"}
+	app := models.App{ID: 1, Name: "test-app"}
+
+	raw, err := NewToken(context.Background(), user, app, time.Hour, keys, Claims{})
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	parsed, err := jwt.Parse(raw, func(*jwt.Token) (interface{}, error) {
+		return keys.verify.PublicKey, nil
+	})
+	if err != nil {
+		t.Fatalf("jwt.Parse() error = %v", err)
+	}
+
+	claims := parsed.Claims.(jwt.MapClaims)
+	if _, ok := claims["roles"]; ok {
+		t.Error("roles claim present despite empty Claims.Roles")
+	}
+	if _, ok := claims["permissions"]; ok {
+		t.Error("permissions claim present despite empty Claims.Permissions")
+	}
+}
+
+func TestGenerateKeyPair_UnsupportedAlgorithm(t *testing.T) {
+	if _, err := GenerateKeyPair("HS256"); err == nil {
+		t.Fatal("GenerateKeyPair(\"HS256\") error = nil, want unsupported algorithm error")
+	}
+}
@@ -0,0 +1,83 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+var ErrInvalidPEM = errors.New("jwt: invalid PEM block")
+
+// pemKeyProvider is a KeyProvider backed by a SigningKeyStorage that stores
+// keys as PEM text; it decodes them into usable crypto values on demand.
+type pemKeyProvider struct {
+	storage SigningKeyStorage
+}
+
+// NewPEMKeyProvider builds a KeyProvider on top of a SigningKeyStorage.
+func NewPEMKeyProvider(storage SigningKeyStorage) KeyProvider {
+	return &pemKeyProvider{storage: storage}
+}
+
+func (p *pemKeyProvider) ActiveSigningKey(ctx context.Context, appID int) (SigningKey, error) {
+	stored, err := p.storage.ActiveSigningKey(ctx, appID)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	signer, err := decodePrivateKeyPEM(stored.PrivatePEM)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{Kid: stored.Kid, Algorithm: stored.Algorithm, PrivateKey: signer}, nil
+}
+
+func (p *pemKeyProvider) VerificationKeys(ctx context.Context, appID int) ([]VerificationKey, error) {
+	stored, err := p.storage.ValidVerificationKeys(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]VerificationKey, 0, len(stored))
+	for _, s := range stored {
+		pub, err := decodePublicKeyPEM(s.PublicPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, VerificationKey{Kid: s.Kid, Algorithm: s.Algorithm, PublicKey: pub})
+	}
+
+	return keys, nil
+}
+
+func decodePrivateKeyPEM(pemStr string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(pemStr))
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidPEM
+	}
+
+	return signer, nil
+}
+
+func decodePublicKeyPEM(pemStr string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemStr))
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
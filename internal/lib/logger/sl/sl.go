@@ -0,0 +1,13 @@
+package sl
+
+import "log/slog"
+
+// Err returns slog attribute with error value, for convenient use in log calls:
+//
+//	log.Error("failed to do something", sl.Err(err))
+func Err(err error) slog.Attr {
+	return slog.Attr{
+		Key:   "error",
+		Value: slog.StringValue(err.Error()),
+	}
+}
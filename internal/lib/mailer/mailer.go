@@ -0,0 +1,12 @@
+// Package mailer sends the transactional emails Auth needs for email
+// verification and password reset, behind a small interface so operators
+// can plug in whatever transport they actually run in production.
+package mailer
+
+import "context"
+
+// Mailer sends a single plain-text email. Implementations are expected to
+// be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, to string, subject string, body string) error
+}
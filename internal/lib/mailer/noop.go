@@ -0,0 +1,26 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NoopMailer logs the email instead of sending it, for local dev and tests
+// where no real mail transport is configured.
+type NoopMailer struct {
+	log *slog.Logger
+}
+
+func NewNoopMailer(log *slog.Logger) *NoopMailer {
+	return &NoopMailer{log: log}
+}
+
+func (m *NoopMailer) Send(_ context.Context, to string, subject string, body string) error {
+	m.log.Info("mailer: would send email",
+		slog.String("to", to),
+		slog.String("subject", subject),
+		slog.String("body", body),
+	)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// ErrHeaderInjection is returned when to or subject contain a CR or LF,
+// which would otherwise let a caller inject extra headers (or recipients)
+// into the raw message.
+var ErrHeaderInjection = errors.New("mailer: to/subject must not contain CR or LF")
+
+// SMTPMailer sends email through a standard SMTP server.
+type SMTPMailer struct {
+	addr string // host:port
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(addr, from, username, password, host string) *SMTPMailer {
+	return &SMTPMailer{
+		addr: addr,
+		from: from,
+		auth: smtp.PlainAuth("", username, password, host),
+	}
+}
+
+func (m *SMTPMailer) Send(_ context.Context, to string, subject string, body string) error {
+	const op = "mailer.SMTPMailer.Send"
+
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("%s: %w", op, ErrHeaderInjection)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.from, to, subject, body)
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
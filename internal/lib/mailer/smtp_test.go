@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSMTPMailer_Send_RejectsHeaderInjection(t *testing.T) {
+	m := NewSMTPMailer("127.0.0.1:0", "from@example.com", "user", "pass", "127.0.0.1")
+
+	tests := []struct {
+		name    string
+		to      string
+		subject string
+	}{
+		{"CRLF in to", "victim@example.com\r\nBcc: attacker@example.com", "Reset your password"},
+		{"LF in subject", "victim@example.com", "Reset your password\nBcc: attacker@example.com"},
+		{"CR in subject", "victim@example.com", "Reset your password\rBcc: attacker@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Send(context.Background(), tt.to, tt.subject, "body")
+			if !errors.Is(err, ErrHeaderInjection) {
+				t.Fatalf("Send() error = %v, want %v", err, ErrHeaderInjection)
+			}
+		})
+	}
+}
+
+func TestSMTPMailer_Send_AllowsCleanHeaders(t *testing.T) {
+	m := NewSMTPMailer("127.0.0.1:0", "from@example.com", "user", "pass", "127.0.0.1")
+
+	err := m.Send(context.Background(), "victim@example.com", "Reset your password", "body")
+	if errors.Is(err, ErrHeaderInjection) {
+		t.Fatalf("Send() returned ErrHeaderInjection for clean headers: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Package ratelimit tracks failed-attempt counters per key (email+app,
+// client IP, ...) so Auth can lock out brute-force callers without caring
+// whether the counter lives in process memory or in Redis.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Policy controls how many attempts a key gets within a window, and how
+// long it stays locked once it has used up that quota.
+type Policy struct {
+	MaxAttempts int
+	Window      time.Duration
+	LockoutFor  time.Duration
+}
+
+// Limiter tracks failed attempts for a key and reports whether it is
+// currently locked out. Policy is passed on every call (rather than fixed
+// at construction) so a single Limiter can enforce a different policy per
+// caller, e.g. one tuned per app.
+type Limiter interface {
+	// Allow reports whether key is currently permitted to attempt a login,
+	// i.e. it has not been locked out by prior failures.
+	Allow(ctx context.Context, key string) (bool, error)
+	// RecordFailure registers a failed attempt for key, locking it out once
+	// policy.MaxAttempts failures are reached within policy.Window.
+	RecordFailure(ctx context.Context, key string, policy Policy) error
+	// Reset clears any recorded failures and lockout for key, called after
+	// a successful attempt.
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter is a single-process Limiter backed by an in-memory
+// per-key bucket. It is meant for local dev / single-instance deployments;
+// a multi-instance deployment should use RedisLimiter instead, since
+// counters here are not shared across processes.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	failures    int
+	windowEnds  time.Time
+	lockedUntil time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket)}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return true, nil
+	}
+
+	return !time.Now().Before(b.lockedUntil), nil
+}
+
+func (l *MemoryLimiter) RecordFailure(_ context.Context, key string, policy Policy) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	b, ok := l.buckets[key]
+	if !ok || now.After(b.windowEnds) {
+		b = &bucket{windowEnds: now.Add(policy.Window)}
+		l.buckets[key] = b
+	}
+
+	b.failures++
+
+	if b.failures >= policy.MaxAttempts {
+		b.lockedUntil = now.Add(policy.LockoutFor)
+	}
+
+	return nil
+}
+
+func (l *MemoryLimiter) Reset(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.buckets, key)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter_AllowsUnderThreshold(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	policy := Policy{MaxAttempts: 3, Window: time.Minute, LockoutFor: time.Minute}
+
+	for i := 0; i < policy.MaxAttempts-1; i++ {
+		if err := l.RecordFailure(ctx, "key", policy); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+
+	allowed, err := l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Fatal("Allow() = false before reaching MaxAttempts, want true")
+	}
+}
+
+func TestMemoryLimiter_LocksOutAtThresholdUntilLockoutExpires(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	policy := Policy{MaxAttempts: 3, Window: time.Minute, LockoutFor: 50 * time.Millisecond}
+
+	for i := 0; i < policy.MaxAttempts; i++ {
+		if err := l.RecordFailure(ctx, "key", policy); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+
+	allowed, err := l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Fatal("Allow() = true right after reaching MaxAttempts, want false")
+	}
+
+	time.Sleep(policy.LockoutFor + 10*time.Millisecond)
+
+	allowed, err = l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Fatal("Allow() = false after LockoutFor elapsed, want true")
+	}
+}
+
+func TestMemoryLimiter_WindowExpiryResetsFailureCount(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	policy := Policy{MaxAttempts: 3, Window: 30 * time.Millisecond, LockoutFor: time.Minute}
+
+	// One failure short of lockout, then let the window lapse before the
+	// next failure: the count must restart from zero, not carry over.
+	for i := 0; i < policy.MaxAttempts-1; i++ {
+		if err := l.RecordFailure(ctx, "key", policy); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+
+	time.Sleep(policy.Window + 10*time.Millisecond)
+
+	if err := l.RecordFailure(ctx, "key", policy); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	allowed, err := l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Fatal("Allow() = false after window reset, want true (failures should not carry across windows)")
+	}
+}
+
+func TestMemoryLimiter_Reset(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	policy := Policy{MaxAttempts: 1, Window: time.Minute, LockoutFor: time.Minute}
+
+	if err := l.RecordFailure(ctx, "key", policy); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	allowed, err := l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Fatal("Allow() = true after reaching MaxAttempts, want false")
+	}
+
+	if err := l.Reset(ctx, "key"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	allowed, err = l.Allow(ctx, "key")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Fatal("Allow() = false after Reset, want true")
+	}
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisLimiter is a Limiter backed by Redis, shared across every instance
+// of the service. Failures are tracked in a sorted set keyed by key so
+// that only failures within the current window count towards the lockout;
+// the lockout itself is a separate key with a TTL equal to policy.LockoutFor.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	const op = "ratelimit.RedisLimiter.Allow"
+
+	n, err := l.client.Exists(ctx, lockKey(key)).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n == 0, nil
+}
+
+func (l *RedisLimiter) RecordFailure(ctx context.Context, key string, policy Policy) error {
+	const op = "ratelimit.RedisLimiter.RecordFailure"
+
+	now := time.Now()
+	window := failuresKey(key)
+
+	pipe := l.client.TxPipeline()
+	pipe.ZAdd(ctx, window, redis.Z{Score: float64(now.UnixNano()), Member: strconv.FormatInt(now.UnixNano(), 10)})
+	pipe.ZRemRangeByScore(ctx, window, "-inf", strconv.FormatInt(now.Add(-policy.Window).UnixNano(), 10))
+	pipe.Expire(ctx, window, policy.Window)
+	count := pipe.ZCard(ctx, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if count.Val() >= int64(policy.MaxAttempts) {
+		if err := l.client.Set(ctx, lockKey(key), 1, policy.LockoutFor).Err(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *RedisLimiter) Reset(ctx context.Context, key string) error {
+	const op = "ratelimit.RedisLimiter.Reset"
+
+	if err := l.client.Del(ctx, failuresKey(key), lockKey(key)).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func failuresKey(key string) string {
+	return "ratelimit:failures:" + key
+}
+
+func lockKey(key string) string {
+	return "ratelimit:lock:" + key
+}
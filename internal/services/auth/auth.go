@@ -5,23 +5,73 @@ import (
 	"auth_grpc/internal/lib/logger/sl"
 	"auth_grpc/internal/storage"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"auth_grpc/internal/lib/hash"
 	"auth_grpc/internal/lib/jwt"
-
-	"golang.org/x/crypto/bcrypt"
+	"auth_grpc/internal/lib/mailer"
+	"auth_grpc/internal/lib/ratelimit"
+	"auth_grpc/internal/services/permissions"
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrTooManyAttempts     = errors.New("too many attempts, try again later")
+	ErrInvalidToken        = errors.New("invalid or expired token")
+	ErrEmailNotVerified    = errors.New("email not verified")
+)
+
+// resetTokenTTL and verifyTokenTTL bound how long a password-reset /
+// email-verification link stays usable before the user has to request a
+// fresh one.
+const (
+	resetTokenTTL  = time.Hour
+	verifyTokenTTL = 24 * time.Hour
 )
 
+// clientIPContextKey is the context key a gRPC interceptor stores the
+// caller's IP under, so Login/RegisterNewUser can rate-limit by IP in
+// addition to by email/app.
+type clientIPContextKey struct{}
+
+// ContextWithClientIP returns a context carrying the caller's IP, to be
+// read by Auth for per-IP rate limiting. A gRPC interceptor is expected to
+// call this for every incoming request.
+func ContextWithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+func clientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
+
+// refreshTokenTTL is how long a refresh token stays valid before the client
+// has to go through Login again.
+const refreshTokenTTL = 30 * 24 * time.Hour
+
 type UserStorage interface {
-	SaveUser(ctx context.Context, email string, passHash []byte) (uid int64, err error)
+	SaveUser(ctx context.Context, email string, passHash []byte, passHashAlgo string) (uid int64, err error)
 	GetUser(ctx context.Context, email string) (models.User, error)
+	GetUserByID(ctx context.Context, userID int64) (models.User, error)
+	UpdatePasswordHash(ctx context.Context, userID int64, passHash []byte, passHashAlgo string) error
+	MarkEmailVerified(ctx context.Context, userID int64) error
+}
+
+// OneTimeTokenStorage хранит одноразовые токены для сброса пароля и
+// подтверждения почты. Как и в RefreshTokenStorage, хранится только хэш
+// значения токена.
+type OneTimeTokenStorage interface {
+	SaveOneTimeToken(ctx context.Context, t models.OneTimeToken) error
+	GetOneTimeToken(ctx context.Context, tokenHash string) (models.OneTimeToken, error)
+	MarkOneTimeTokenUsed(ctx context.Context, tokenHash string) error
 }
 
 // интерфейс для получения App из хранилища:
@@ -29,27 +79,92 @@ type AppProvider interface {
 	GetAppInfo(ctx context.Context, appID int) (models.App, error)
 }
 
+// RefreshTokenStorage хранит refresh-токены. Токен никогда не хранится в
+// открытом виде — на вход/выход всех методов идёт только его хэш.
+type RefreshTokenStorage interface {
+	SaveRefreshToken(ctx context.Context, rt models.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string) error
+	RevokeAllForUser(ctx context.Context, userID int64, appID int) error
+	RevokeAllForUserAllApps(ctx context.Context, userID int64) error
+}
+
 type Auth struct {
-	log         *slog.Logger
-	userStorage UserStorage
-	appProvider AppProvider
-	tokenTTL    time.Duration
+	log                 *slog.Logger
+	userStorage         UserStorage
+	appProvider         AppProvider
+	refreshTokenStorage RefreshTokenStorage
+	hasher              hash.Hasher
+	keyProvider         jwt.KeyProvider
+	limiter             ratelimit.Limiter
+	registerPolicy      ratelimit.Policy
+	authz               *permissions.AuthZ
+	oneTimeTokenStorage OneTimeTokenStorage
+	mailer              mailer.Mailer
+	tokenTTL            time.Duration
 }
 
 func New(
 	log *slog.Logger,
 	userStorage UserStorage,
 	appProvider AppProvider,
+	refreshTokenStorage RefreshTokenStorage,
+	hasher hash.Hasher,
+	keyProvider jwt.KeyProvider,
+	limiter ratelimit.Limiter,
+	registerPolicy ratelimit.Policy,
+	authz *permissions.AuthZ,
+	oneTimeTokenStorage OneTimeTokenStorage,
+	mailer mailer.Mailer,
 	tokenTTL time.Duration,
 ) *Auth {
 	return &Auth{
-		userStorage: userStorage,
-		log:         log,
-		appProvider: appProvider,
-		tokenTTL:    tokenTTL, // Время жизни возвращаемых токенов
+		userStorage:         userStorage,
+		log:                 log,
+		appProvider:         appProvider,
+		refreshTokenStorage: refreshTokenStorage,
+		hasher:              hasher,
+		keyProvider:         keyProvider,
+		limiter:             limiter,
+		registerPolicy:      registerPolicy,
+		authz:               authz,
+		oneTimeTokenStorage: oneTimeTokenStorage,
+		mailer:              mailer,
+		tokenTTL:            tokenTTL, // Время жизни возвращаемых токенов
 	}
 }
 
+// IsAdmin reports whether the user identified by userID holds the admin
+// role in appID.
+func (a *Auth) IsAdmin(ctx context.Context, userID int64, appID int) (bool, error) {
+	return a.authz.IsAdmin(ctx, userID, appID)
+}
+
+// HasPermission reports whether the user identified by userID holds a
+// role granting permission in appID.
+func (a *Auth) HasPermission(ctx context.Context, userID int64, appID int, permission string) (bool, error) {
+	return a.authz.HasPermission(ctx, userID, appID, permission)
+}
+
+// newRefreshToken generates a random refresh token and returns both the raw
+// value (handed to the client) and its sha256 hash (the only thing we persist).
+func newRefreshToken() (raw string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	raw = hex.EncodeToString(b)
+	sum := sha256.Sum256([]byte(raw))
+
+	return raw, hex.EncodeToString(sum[:]), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
 // RegisterNewUser registers new user in the system and returns user ID.
 // If user with given username already exists, returns error.
 func (a *Auth) RegisterNewUser(ctx context.Context, email string, pass string) (int64, error) {
@@ -68,8 +183,21 @@ func (a *Auth) RegisterNewUser(ctx context.Context, email string, pass string) (
 
 	log.Info("registering user")
 
-	// Генерируем хэш и соль для пароля.
-	passHash, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.DefaultCost)
+	var ipKey string
+	if a.registerPolicy.MaxAttempts > 0 {
+		ipKey = registerIPKey(clientIPFromContext(ctx))
+	}
+	if ipKey != "" {
+		allowed, err := a.limiter.Allow(ctx, ipKey)
+		if err != nil {
+			log.Error("failed to check rate limit", sl.Err(err))
+		} else if !allowed {
+			return 0, fmt.Errorf("%s: %w", op, ErrTooManyAttempts)
+		}
+	}
+
+	// Генерируем хэш пароля выбранным в конфиге алгоритмом (bcrypt/argon2id/scrypt).
+	passHash, err := a.hasher.Hash(pass)
 	if err != nil {
 		log.Error("failed to generate password hash", sl.Err(err))
 
@@ -77,17 +205,38 @@ func (a *Auth) RegisterNewUser(ctx context.Context, email string, pass string) (
 	}
 
 	// Сохраняем пользователя в БД
-	id, err := a.userStorage.SaveUser(ctx, email, passHash)
+	id, err := a.userStorage.SaveUser(ctx, email, []byte(passHash), a.hasher.Algorithm())
 	if err != nil {
 		log.Error("failed to save user", sl.Err(err))
 
+		if ipKey != "" {
+			if rlErr := a.limiter.RecordFailure(ctx, ipKey, a.registerPolicy); rlErr != nil {
+				log.Error("failed to record rate limit failure", sl.Err(rlErr))
+			}
+		}
+
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if ipKey != "" {
+		if err := a.limiter.Reset(ctx, ipKey); err != nil {
+			log.Error("failed to reset rate limit", sl.Err(err))
+		}
+	}
+
 	return id, nil
 }
 
-// Login checks if user with given credentials exists in the system and returns access token.
+func registerIPKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	return "register:ip:" + ip
+}
+
+// Login checks if user with given credentials exists in the system and
+// returns a fresh access/refresh token pair.
 //
 // If user exists, but password is incorrect, returns error.
 // If user doesn't exist, returns error.
@@ -96,7 +245,7 @@ func (a *Auth) Login(
 	email string,
 	password string, // пароль в чистом виде, аккуратней с логами!
 	appID int, // ID приложения, в котором логинится пользователь
-) (string, error) {
+) (accessToken string, refreshToken string, err error) {
 	const op = "Auth.Login"
 
 	log := a.log.With(
@@ -107,42 +256,437 @@ func (a *Auth) Login(
 
 	log.Info("attempting to login user")
 
+	// Получаем информацию о приложении — нужна и для выпуска токена, и для
+	// того, чтобы знать политику лимита попыток для этого app_id.
+	app, err := a.appProvider.GetAppInfo(ctx, appID)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	limitKeys := a.loginLimitKeys(ctx, app, email)
+	for _, key := range limitKeys {
+		allowed, err := a.limiter.Allow(ctx, key)
+		if err != nil {
+			log.Error("failed to check rate limit", sl.Err(err))
+
+			continue
+		}
+
+		if !allowed {
+			log.Warn("login blocked by rate limiter", slog.String("key", key))
+
+			return "", "", fmt.Errorf("%s: %w", op, ErrTooManyAttempts)
+		}
+	}
+
 	// Достаём пользователя из БД
 	user, err := a.userStorage.GetUser(ctx, email)
 	if err != nil {
 		if err == storage.ErrUserNotFound {
 			a.log.Warn("user not found", sl.Err(err))
 
-			return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+			a.recordLoginFailure(ctx, limitKeys, app)
+
+			return "", "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
 		}
 
 		a.log.Error("failed to get user", sl.Err(err))
 
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Проверяем корректность полученного пароля
-	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
+	if err := a.hasher.Compare(string(user.PassHash), password); err != nil {
 		a.log.Info("invalid credentials", sl.Err(err))
 
-		return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+		a.recordLoginFailure(ctx, limitKeys, app)
+
+		return "", "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+
+	a.resetLoginLimiter(ctx, limitKeys)
+
+	if app.RequireVerifiedEmail && !user.EmailVerified {
+		log.Warn("login rejected: email not verified")
+
+		return "", "", fmt.Errorf("%s: %w", op, ErrEmailNotVerified)
+	}
+
+	// Пароль верный, но хэш устарел (старый алгоритм или ослабленные параметры) —
+	// перехэшируем его текущей политикой и сохраняем, не прерывая логин.
+	if a.hasher.NeedsRehash(string(user.PassHash)) {
+		if newHash, err := a.hasher.Hash(password); err != nil {
+			log.Error("failed to rehash password", sl.Err(err))
+		} else if err := a.userStorage.UpdatePasswordHash(ctx, user.ID, []byte(newHash), a.hasher.Algorithm()); err != nil {
+			log.Error("failed to persist rehashed password", sl.Err(err))
+		} else {
+			log.Info("password rehashed with current policy")
+		}
+	}
+
+	log.Info("user logged in successfully")
+
+	accessToken, refreshToken, err = a.issueTokenPair(ctx, user, app)
+	if err != nil {
+		a.log.Error("failed to generate token pair", sl.Err(err))
+
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// loginLimitKeys returns the rate-limit keys that apply to a login attempt
+// for app: one per (email, app_id) and, if the caller's IP is known, one
+// per IP. Returns nil if lockout is disabled for app (LoginMaxAttempts <= 0).
+func (a *Auth) loginLimitKeys(ctx context.Context, app models.App, email string) []string {
+	if app.LoginMaxAttempts <= 0 {
+		return nil
+	}
+
+	keys := []string{loginEmailKey(app.ID, email)}
+	if ip := clientIPFromContext(ctx); ip != "" {
+		keys = append(keys, loginIPKey(ip))
+	}
+
+	return keys
+}
+
+func (a *Auth) recordLoginFailure(ctx context.Context, keys []string, app models.App) {
+	policy := ratelimit.Policy{
+		MaxAttempts: app.LoginMaxAttempts,
+		Window:      app.LoginWindow,
+		LockoutFor:  app.LoginLockoutFor,
+	}
+
+	for _, key := range keys {
+		if err := a.limiter.RecordFailure(ctx, key, policy); err != nil {
+			a.log.Error("failed to record rate limit failure", sl.Err(err))
+		}
+	}
+}
+
+func (a *Auth) resetLoginLimiter(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := a.limiter.Reset(ctx, key); err != nil {
+			a.log.Error("failed to reset rate limit", sl.Err(err))
+		}
+	}
+}
+
+func loginEmailKey(appID int, email string) string {
+	return fmt.Sprintf("login:email:%d:%s", appID, email)
+}
+
+func loginIPKey(ip string) string {
+	return "login:ip:" + ip
+}
+
+// issueTokenPair signs a new access token and saves a fresh refresh token
+// for the given user/app, returning the raw values to hand back to the client.
+func (a *Auth) issueTokenPair(ctx context.Context, user models.User, app models.App) (string, string, error) {
+	roles, perms, err := a.authz.ResolveClaims(ctx, user.ID, app.ID)
+	if err != nil {
+		a.log.Error("failed to resolve authorization claims", sl.Err(err))
+
+		// Minting a token with empty claims on a ResolveClaims failure would
+		// be indistinguishable from an unprivileged user for the whole
+		// access-token TTL — fail the login instead of issuing one.
+		return "", "", fmt.Errorf("resolve claims: %w", err)
+	}
+
+	accessToken, err := jwt.NewToken(ctx, user, app, a.tokenTTL, a.keyProvider, jwt.Claims{Roles: roles, Permissions: perms})
+	if err != nil {
+		return "", "", err
+	}
+
+	rawRefreshToken, refreshTokenHash, err := newRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = a.refreshTokenStorage.SaveRefreshToken(ctx, models.RefreshToken{
+		UserID:    user.ID,
+		AppID:     app.ID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, rawRefreshToken, nil
+}
+
+// RefreshTokens exchanges a valid, unused refresh token for a new access/refresh
+// token pair, rotating the refresh token on every use.
+//
+// If the presented refresh token has already been rotated away (i.e. it was
+// already used once before), this is treated as a sign that the token leaked:
+// the whole refresh-token family for that user/app is revoked and the caller
+// must log in again.
+func (a *Auth) RefreshTokens(ctx context.Context, refreshToken string, appID int) (accessToken string, newRefreshToken string, err error) {
+	const op = "Auth.RefreshTokens"
+
+	log := a.log.With(slog.String("op", op))
+
+	tokenHash := hashToken(refreshToken)
+
+	rt, err := a.refreshTokenStorage.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			return "", "", fmt.Errorf("%s: %w", op, ErrInvalidRefreshToken)
+		}
+
+		log.Error("failed to get refresh token", sl.Err(err))
+
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rt.AppID != appID {
+		return "", "", fmt.Errorf("%s: %w", op, ErrInvalidRefreshToken)
+	}
+
+	if rt.RevokedAt != nil {
+		// Токен уже был использован ранее — считаем это компрометацией и
+		// отзываем все refresh-токены этого пользователя в данном приложении.
+		log.Warn("refresh token reuse detected, revoking all tokens for user", slog.Int64("user_id", rt.UserID))
+
+		if err := a.refreshTokenStorage.RevokeAllForUser(ctx, rt.UserID, rt.AppID); err != nil {
+			log.Error("failed to revoke refresh tokens after reuse", sl.Err(err))
+		}
+
+		return "", "", fmt.Errorf("%s: %w", op, ErrInvalidRefreshToken)
+	}
+
+	if time.Now().After(rt.ExpiresAt) {
+		return "", "", fmt.Errorf("%s: %w", op, ErrInvalidRefreshToken)
 	}
 
-	// Получаем информацию о приложении
 	app, err := a.appProvider.GetAppInfo(ctx, appID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("user logged in successfully")
+	user, err := a.userStorage.GetUserByID(ctx, rt.UserID)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Отзываем использованный токен перед выдачей нового — ротация.
+	if err := a.refreshTokenStorage.RevokeRefreshToken(ctx, tokenHash); err != nil {
+		log.Error("failed to revoke rotated refresh token", sl.Err(err))
+
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	accessToken, newRefreshToken, err = a.issueTokenPair(ctx, user, app)
+	if err != nil {
+		log.Error("failed to issue new token pair", sl.Err(err))
+
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return accessToken, newRefreshToken, nil
+}
+
+// Logout revokes the given refresh token, so it can no longer be exchanged
+// for a new access token.
+func (a *Auth) Logout(ctx context.Context, refreshToken string) error {
+	const op = "Auth.Logout"
+
+	tokenHash := hashToken(refreshToken)
+
+	if err := a.refreshTokenStorage.RevokeRefreshToken(ctx, tokenHash); err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrInvalidRefreshToken)
+		}
 
-	// Создаём токен авторизации
-	token, err := jwt.NewToken(user, app, a.tokenTTL)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// newOneTimeToken generates a random one-time token and returns both the
+// raw value (sent to the user by email) and its sha256 hash (the only
+// thing persisted). Opaque tokens are generated the same way everywhere
+// in this package, so this just delegates to newRefreshToken.
+func newOneTimeToken() (raw string, hash string, err error) {
+	return newRefreshToken()
+}
+
+// RequestPasswordReset issues a one-time reset token for the user with the
+// given email and emails it to them. It does not report whether the email
+// exists, so callers can't use it to enumerate accounts.
+func (a *Auth) RequestPasswordReset(ctx context.Context, email string, appID int) error {
+	const op = "Auth.RequestPasswordReset"
+
+	log := a.log.With(slog.String("op", op), slog.String("email", email), slog.Int("app_id", appID))
+
+	user, err := a.userStorage.GetUser(ctx, email)
 	if err != nil {
-		a.log.Error("failed to generate token", sl.Err(err))
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("password reset requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rawToken, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.oneTimeTokenStorage.SaveOneTimeToken(ctx, models.OneTimeToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		Purpose:   models.OneTimeTokenPurposeReset,
+		ExpiresAt: time.Now().Add(resetTokenTTL),
+	})
+	if err != nil {
+		log.Error("failed to save password reset token", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.mailer.Send(ctx, user.Email, "Reset your password",
+		fmt.Sprintf("Use this token to reset your password: %s\nIt expires in %s.", rawToken, resetTokenTTL),
+	); err != nil {
+		log.Error("failed to send password reset email", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetPassword consumes a one-time reset token and sets newPassword as
+// the user's password.
+func (a *Auth) ResetPassword(ctx context.Context, resetToken string, newPassword string) error {
+	const op = "Auth.ResetPassword"
+
+	log := a.log.With(slog.String("op", op))
+
+	t, err := a.consumeOneTimeToken(ctx, resetToken, models.OneTimeTokenPurposeReset)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := a.hasher.Hash(newPassword)
+	if err != nil {
+		log.Error("failed to hash new password", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.userStorage.UpdatePasswordHash(ctx, t.UserID, []byte(passHash), a.hasher.Algorithm()); err != nil {
+		log.Error("failed to persist new password", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// A password reset means the old password may have been compromised, so
+	// every refresh token issued before it — in every app — must stop working.
+	if err := a.refreshTokenStorage.RevokeAllForUserAllApps(ctx, t.UserID); err != nil {
+		log.Error("failed to revoke refresh tokens after password reset", sl.Err(err))
+	}
+
+	return nil
+}
+
+// SendVerificationEmail issues a one-time verification token for userID
+// and emails it to them.
+func (a *Auth) SendVerificationEmail(ctx context.Context, userID int64) error {
+	const op = "Auth.SendVerificationEmail"
+
+	log := a.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	user, err := a.userStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	rawToken, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.oneTimeTokenStorage.SaveOneTimeToken(ctx, models.OneTimeToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		Purpose:   models.OneTimeTokenPurposeVerify,
+		ExpiresAt: time.Now().Add(verifyTokenTTL),
+	})
+	if err != nil {
+		log.Error("failed to save verification token", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.mailer.Send(ctx, user.Email, "Verify your email",
+		fmt.Sprintf("Use this token to verify your email: %s\nIt expires in %s.", rawToken, verifyTokenTTL),
+	); err != nil {
+		log.Error("failed to send verification email", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// VerifyEmail consumes a one-time verification token and marks the
+// corresponding user's email as verified.
+func (a *Auth) VerifyEmail(ctx context.Context, verificationToken string) error {
+	const op = "Auth.VerifyEmail"
+
+	log := a.log.With(slog.String("op", op))
+
+	t, err := a.consumeOneTimeToken(ctx, verificationToken, models.OneTimeTokenPurposeVerify)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.userStorage.MarkEmailVerified(ctx, t.UserID); err != nil {
+		log.Error("failed to mark email verified", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// consumeOneTimeToken looks up raw by its hash, checks it matches purpose,
+// hasn't expired and hasn't already been used, and marks it used.
+func (a *Auth) consumeOneTimeToken(ctx context.Context, raw string, purpose string) (models.OneTimeToken, error) {
+	tokenHash := hashToken(raw)
+
+	t, err := a.oneTimeTokenStorage.GetOneTimeToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrOneTimeTokenNotFound) {
+			return models.OneTimeToken{}, ErrInvalidToken
+		}
+
+		return models.OneTimeToken{}, err
+	}
+
+	if t.Purpose != purpose || t.UsedAt != nil || time.Now().After(t.ExpiresAt) {
+		return models.OneTimeToken{}, ErrInvalidToken
+	}
 
-		return "", fmt.Errorf("%s: %w", op, err)
+	if err := a.oneTimeTokenStorage.MarkOneTimeTokenUsed(ctx, tokenHash); err != nil {
+		return models.OneTimeToken{}, err
 	}
 
-	return token, nil
+	return t, nil
 }
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/lib/ratelimit"
+	"auth_grpc/internal/storage"
+)
+
+// fakeRefreshTokenStorage is an in-memory RefreshTokenStorage used to drive
+// Auth.RefreshTokens without a real database.
+type fakeRefreshTokenStorage struct {
+	byHash            map[string]models.RefreshToken
+	revokedAllForUser bool
+	revokedUserID     int64
+	revokedAppID      int
+}
+
+func newFakeRefreshTokenStorage() *fakeRefreshTokenStorage {
+	return &fakeRefreshTokenStorage{byHash: make(map[string]models.RefreshToken)}
+}
+
+func (f *fakeRefreshTokenStorage) SaveRefreshToken(_ context.Context, rt models.RefreshToken) error {
+	f.byHash[rt.TokenHash] = rt
+	return nil
+}
+
+func (f *fakeRefreshTokenStorage) GetRefreshToken(_ context.Context, tokenHash string) (models.RefreshToken, error) {
+	rt, ok := f.byHash[tokenHash]
+	if !ok {
+		return models.RefreshToken{}, storage.ErrRefreshTokenNotFound
+	}
+	return rt, nil
+}
+
+func (f *fakeRefreshTokenStorage) RevokeRefreshToken(_ context.Context, tokenHash string) error {
+	rt, ok := f.byHash[tokenHash]
+	if !ok {
+		return storage.ErrRefreshTokenNotFound
+	}
+	now := time.Now()
+	rt.RevokedAt = &now
+	f.byHash[tokenHash] = rt
+	return nil
+}
+
+func (f *fakeRefreshTokenStorage) RevokeAllForUser(_ context.Context, userID int64, appID int) error {
+	f.revokedAllForUser = true
+	f.revokedUserID = userID
+	f.revokedAppID = appID
+
+	now := time.Now()
+	for hash, rt := range f.byHash {
+		if rt.UserID == userID && rt.AppID == appID && rt.RevokedAt == nil {
+			rt.RevokedAt = &now
+			f.byHash[hash] = rt
+		}
+	}
+	return nil
+}
+
+func (f *fakeRefreshTokenStorage) RevokeAllForUserAllApps(_ context.Context, userID int64) error {
+	now := time.Now()
+	for hash, rt := range f.byHash {
+		if rt.UserID == userID && rt.RevokedAt == nil {
+			rt.RevokedAt = &now
+			f.byHash[hash] = rt
+		}
+	}
+	return nil
+}
+
+type fakeUserStorage struct{}
+
+func (fakeUserStorage) SaveUser(context.Context, string, []byte, string) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (fakeUserStorage) GetUser(context.Context, string) (models.User, error) {
+	return models.User{}, errors.New("not implemented")
+}
+
+func (fakeUserStorage) GetUserByID(_ context.Context, userID int64) (models.User, error) {
+	return models.User{ID: userID, Email: "user@example.com"}, nil
+}
+
+func (fakeUserStorage) UpdatePasswordHash(context.Context, int64, []byte, string) error {
+	return errors.New("not implemented")
+}
+
+func (fakeUserStorage) MarkEmailVerified(context.Context, int64) error {
+	return errors.New("not implemented")
+}
+
+type fakeAppProvider struct{}
+
+func (fakeAppProvider) GetAppInfo(_ context.Context, appID int) (models.App, error) {
+	return models.App{ID: appID, Name: "test-app"}, nil
+}
+
+func newTestAuth(rts *fakeRefreshTokenStorage) *Auth {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+
+	return New(log, fakeUserStorage{}, fakeAppProvider{}, rts, nil, nil, nil, ratelimit.Policy{}, nil, nil, nil, time.Hour)
+}
+
+// TestRefreshTokens_ReuseRevokesWholeFamily verifies that presenting a
+// refresh token that has already been rotated (RevokedAt set) is treated
+// as a compromise signal: the whole token family for that user/app is
+// revoked and the caller gets ErrInvalidRefreshToken rather than a fresh
+// token pair.
+func TestRefreshTokens_ReuseRevokesWholeFamily(t *testing.T) {
+	rts := newFakeRefreshTokenStorage()
+
+	const userID, appID = int64(1), 42
+
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	rawReused := "raw-reused-token"
+	rts.byHash[hashToken(rawReused)] = models.RefreshToken{
+		ID:        1,
+		UserID:    userID,
+		AppID:     appID,
+		TokenHash: hashToken(rawReused),
+		ExpiresAt: now.Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+
+	rawLive := "raw-live-token"
+	rts.byHash[hashToken(rawLive)] = models.RefreshToken{
+		ID:        2,
+		UserID:    userID,
+		AppID:     appID,
+		TokenHash: hashToken(rawLive),
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	a := newTestAuth(rts)
+
+	_, _, err := a.RefreshTokens(context.Background(), rawReused, appID)
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("RefreshTokens() error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+
+	if !rts.revokedAllForUser {
+		t.Fatal("RefreshTokens() did not revoke the token family on reuse")
+	}
+	if rts.revokedUserID != userID || rts.revokedAppID != appID {
+		t.Fatalf("RevokeAllForUser called with (user=%d, app=%d), want (user=%d, app=%d)",
+			rts.revokedUserID, rts.revokedAppID, userID, appID)
+	}
+
+	live := rts.byHash[hashToken(rawLive)]
+	if live.RevokedAt == nil {
+		t.Fatal("reuse detection did not revoke the still-live sibling token")
+	}
+}
@@ -0,0 +1,105 @@
+// Package permissions implements role-based authorization on top of Auth:
+// roles are scoped per app, each role grants a set of permission strings,
+// and users are assigned zero or more roles within an app.
+package permissions
+
+import (
+	"context"
+
+	"auth_grpc/internal/domain/models"
+)
+
+// AdminRole is the role name IsAdmin checks for.
+const AdminRole = "admin"
+
+// PermissionStorage persists roles, their permissions, and which users
+// hold which roles in which app.
+type PermissionStorage interface {
+	CreateRole(ctx context.Context, appID int, name string) (roleID int64, err error)
+	GrantPermission(ctx context.Context, roleID int64, permission string) error
+	AddUserRole(ctx context.Context, userID int64, roleID int64) error
+	RemoveUserRole(ctx context.Context, userID int64, roleID int64) error
+	ListUserRoles(ctx context.Context, userID int64, appID int) ([]models.Role, error)
+	GetRolePermissions(ctx context.Context, roleID int64) ([]string, error)
+}
+
+// AuthZ answers authorization questions ("is this user an admin", "can
+// this user do X") for a given user/app, and resolves the role/permission
+// claims Auth embeds into the JWT it issues on login.
+type AuthZ struct {
+	storage PermissionStorage
+}
+
+func New(storage PermissionStorage) *AuthZ {
+	return &AuthZ{storage: storage}
+}
+
+// IsAdmin reports whether userID holds the admin role in appID.
+func (a *AuthZ) IsAdmin(ctx context.Context, userID int64, appID int) (bool, error) {
+	roles, err := a.storage.ListUserRoles(ctx, userID, appID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if role.Name == AdminRole {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// HasPermission reports whether any role userID holds in appID grants permission.
+func (a *AuthZ) HasPermission(ctx context.Context, userID int64, appID int, permission string) (bool, error) {
+	roles, err := a.storage.ListUserRoles(ctx, userID, appID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		rolePermissions, err := a.storage.GetRolePermissions(ctx, role.ID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, p := range rolePermissions {
+			if p == permission {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// ResolveClaims collects every role name and permission userID holds in
+// appID, deduplicated, for embedding into the JWT issued on login.
+func (a *AuthZ) ResolveClaims(ctx context.Context, userID int64, appID int) (roleNames []string, permissionNames []string, err error) {
+	roles, err := a.storage.ListUserRoles(ctx, userID, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seenPermissions := make(map[string]struct{})
+
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+
+		rolePermissions, err := a.storage.GetRolePermissions(ctx, role.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, p := range rolePermissions {
+			if _, ok := seenPermissions[p]; ok {
+				continue
+			}
+
+			seenPermissions[p] = struct{}{}
+			permissionNames = append(permissionNames, p)
+		}
+	}
+
+	return roleNames, permissionNames, nil
+}
@@ -0,0 +1,174 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"auth_grpc/internal/domain/models"
+)
+
+// fakePermissionStorage is an in-memory PermissionStorage for exercising
+// AuthZ's resolution logic without a real database. Setting listErr makes
+// ListUserRoles fail, for exercising AuthZ's error propagation.
+type fakePermissionStorage struct {
+	roles           map[int64]models.Role
+	userRoles       map[int64][]int64 // userID -> roleIDs
+	rolePermissions map[int64][]string
+	listErr         error
+}
+
+func newFakePermissionStorage() *fakePermissionStorage {
+	return &fakePermissionStorage{
+		roles:           make(map[int64]models.Role),
+		userRoles:       make(map[int64][]int64),
+		rolePermissions: make(map[int64][]string),
+	}
+}
+
+func (f *fakePermissionStorage) CreateRole(_ context.Context, appID int, name string) (int64, error) {
+	id := int64(len(f.roles) + 1)
+	f.roles[id] = models.Role{ID: id, AppID: appID, Name: name}
+	return id, nil
+}
+
+func (f *fakePermissionStorage) GrantPermission(_ context.Context, roleID int64, permission string) error {
+	f.rolePermissions[roleID] = append(f.rolePermissions[roleID], permission)
+	return nil
+}
+
+func (f *fakePermissionStorage) AddUserRole(_ context.Context, userID int64, roleID int64) error {
+	f.userRoles[userID] = append(f.userRoles[userID], roleID)
+	return nil
+}
+
+func (f *fakePermissionStorage) RemoveUserRole(_ context.Context, userID int64, roleID int64) error {
+	kept := f.userRoles[userID][:0]
+	for _, id := range f.userRoles[userID] {
+		if id != roleID {
+			kept = append(kept, id)
+		}
+	}
+	f.userRoles[userID] = kept
+	return nil
+}
+
+func (f *fakePermissionStorage) ListUserRoles(_ context.Context, userID int64, appID int) ([]models.Role, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+
+	var roles []models.Role
+	for _, roleID := range f.userRoles[userID] {
+		if role, ok := f.roles[roleID]; ok && role.AppID == appID {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func (f *fakePermissionStorage) GetRolePermissions(_ context.Context, roleID int64) ([]string, error) {
+	return f.rolePermissions[roleID], nil
+}
+
+func TestAuthZ_IsAdmin(t *testing.T) {
+	storage := newFakePermissionStorage()
+	a := New(storage)
+	ctx := context.Background()
+
+	const appID = 1
+	adminRoleID, _ := storage.CreateRole(ctx, appID, AdminRole)
+	memberRoleID, _ := storage.CreateRole(ctx, appID, "member")
+
+	_ = storage.AddUserRole(ctx, 1, adminRoleID)
+	_ = storage.AddUserRole(ctx, 2, memberRoleID)
+
+	isAdmin, err := a.IsAdmin(ctx, 1, appID)
+	if err != nil {
+		t.Fatalf("IsAdmin() error = %v", err)
+	}
+	if !isAdmin {
+		t.Error("IsAdmin() = false for a user holding the admin role")
+	}
+
+	isAdmin, err = a.IsAdmin(ctx, 2, appID)
+	if err != nil {
+		t.Fatalf("IsAdmin() error = %v", err)
+	}
+	if isAdmin {
+		t.Error("IsAdmin() = true for a user without the admin role")
+	}
+}
+
+func TestAuthZ_HasPermission(t *testing.T) {
+	storage := newFakePermissionStorage()
+	a := New(storage)
+	ctx := context.Background()
+
+	const appID = 1
+	roleID, _ := storage.CreateRole(ctx, appID, "editor")
+	_ = storage.GrantPermission(ctx, roleID, "posts:write")
+	_ = storage.AddUserRole(ctx, 1, roleID)
+
+	has, err := a.HasPermission(ctx, 1, appID, "posts:write")
+	if err != nil {
+		t.Fatalf("HasPermission() error = %v", err)
+	}
+	if !has {
+		t.Error("HasPermission() = false for a permission granted via the user's role")
+	}
+
+	has, err = a.HasPermission(ctx, 1, appID, "posts:delete")
+	if err != nil {
+		t.Fatalf("HasPermission() error = %v", err)
+	}
+	if has {
+		t.Error("HasPermission() = true for a permission never granted")
+	}
+}
+
+func TestAuthZ_ResolveClaims_DeduplicatesPermissions(t *testing.T) {
+	storage := newFakePermissionStorage()
+	a := New(storage)
+	ctx := context.Background()
+
+	const appID = 1
+	editorID, _ := storage.CreateRole(ctx, appID, "editor")
+	_ = storage.GrantPermission(ctx, editorID, "posts:write")
+	_ = storage.GrantPermission(ctx, editorID, "posts:read")
+
+	reviewerID, _ := storage.CreateRole(ctx, appID, "reviewer")
+	_ = storage.GrantPermission(ctx, reviewerID, "posts:read") // overlaps with editor
+
+	_ = storage.AddUserRole(ctx, 1, editorID)
+	_ = storage.AddUserRole(ctx, 1, reviewerID)
+
+	roles, permissions, err := a.ResolveClaims(ctx, 1, appID)
+	if err != nil {
+		t.Fatalf("ResolveClaims() error = %v", err)
+	}
+
+	sort.Strings(roles)
+	sort.Strings(permissions)
+
+	if len(roles) != 2 || roles[0] != "editor" || roles[1] != "reviewer" {
+		t.Errorf("roles = %v, want [editor reviewer]", roles)
+	}
+
+	if len(permissions) != 2 || permissions[0] != "posts:read" || permissions[1] != "posts:write" {
+		t.Errorf("permissions = %v, want [posts:read posts:write] (deduplicated), got duplicates or wrong set", permissions)
+	}
+}
+
+func TestAuthZ_ResolveClaims_PropagatesStorageError(t *testing.T) {
+	wantErr := errors.New("storage unavailable")
+	storage := newFakePermissionStorage()
+	storage.listErr = wantErr
+	a := New(storage)
+
+	_, _, err := a.ResolveClaims(context.Background(), 1, 1)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("ResolveClaims() error = %v, want %v", err, wantErr)
+	}
+}
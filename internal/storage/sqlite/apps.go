@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/storage"
+)
+
+// GetAppInfo returns the app registered under appID, including its
+// brute-force lockout policy and email-verification requirement.
+func (s *Storage) GetAppInfo(ctx context.Context, appID int) (models.App, error) {
+	const op = "sqlite.GetAppInfo"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, name, login_max_attempts, login_window_seconds, login_lockout_for_seconds, require_verified_email
+		FROM apps
+		WHERE id = ?
+	`, appID)
+
+	var (
+		app               models.App
+		windowSeconds     int64
+		lockoutForSeconds int64
+	)
+
+	err := row.Scan(&app.ID, &app.Name, &app.LoginMaxAttempts, &windowSeconds, &lockoutForSeconds, &app.RequireVerifiedEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.App{}, fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+		}
+
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	app.LoginWindow = time.Duration(windowSeconds) * time.Second
+	app.LoginLockoutFor = time.Duration(lockoutForSeconds) * time.Second
+
+	return app, nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/storage"
+)
+
+// SaveOneTimeToken persists a freshly generated password-reset or
+// email-verification token.
+func (s *Storage) SaveOneTimeToken(ctx context.Context, t models.OneTimeToken) error {
+	const op = "sqlite.SaveOneTimeToken"
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO one_time_tokens (user_id, token_hash, purpose, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, t.UserID, t.TokenHash, t.Purpose, t.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetOneTimeToken returns the token stored under tokenHash.
+func (s *Storage) GetOneTimeToken(ctx context.Context, tokenHash string) (models.OneTimeToken, error) {
+	const op = "sqlite.GetOneTimeToken"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, user_id, token_hash, purpose, expires_at, used_at
+		FROM one_time_tokens
+		WHERE token_hash = ?
+	`, tokenHash)
+
+	var t models.OneTimeToken
+	if err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &t.Purpose, &t.ExpiresAt, &t.UsedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, storage.ErrOneTimeTokenNotFound)
+		}
+
+		return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return t, nil
+}
+
+// MarkOneTimeTokenUsed marks the token stored under tokenHash as used, so
+// it can't be consumed a second time.
+func (s *Storage) MarkOneTimeTokenUsed(ctx context.Context, tokenHash string) error {
+	const op = "sqlite.MarkOneTimeTokenUsed"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE one_time_tokens SET used_at = CURRENT_TIMESTAMP WHERE token_hash = ?
+	`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
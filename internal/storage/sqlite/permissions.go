@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/storage"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// CreateRole creates a new role named name, scoped to appID.
+func (s *Storage) CreateRole(ctx context.Context, appID int, name string) (int64, error) {
+	const op = "sqlite.CreateRole"
+
+	res, err := s.db.ExecContext(ctx, `INSERT INTO roles (app_id, name) VALUES (?, ?)`, appID, name)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrRoleExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GrantPermission grants permission to roleID, creating the permission row
+// if it doesn't exist yet.
+func (s *Storage) GrantPermission(ctx context.Context, roleID int64, permission string) error {
+	const op = "sqlite.GrantPermission"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO permissions (name) VALUES (?)`, permission); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var permissionID int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM permissions WHERE name = ?`, permission).Scan(&permissionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO role_permissions (role_id, permission_id) VALUES (?, ?)`,
+		roleID, permissionID,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddUserRole grants roleID to userID.
+func (s *Storage) AddUserRole(ctx context.Context, userID int64, roleID int64) error {
+	const op = "sqlite.AddUserRole"
+
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO user_roles (user_id, role_id) VALUES (?, ?)`,
+		userID, roleID,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveUserRole revokes roleID from userID.
+func (s *Storage) RemoveUserRole(ctx context.Context, userID int64, roleID int64) error {
+	const op = "sqlite.RemoveUserRole"
+
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM user_roles WHERE user_id = ? AND role_id = ?`,
+		userID, roleID,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListUserRoles returns every role userID holds within appID.
+func (s *Storage) ListUserRoles(ctx context.Context, userID int64, appID int) ([]models.Role, error) {
+	const op = "sqlite.ListUserRoles"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT roles.id, roles.app_id, roles.name
+		FROM roles
+		JOIN user_roles ON user_roles.role_id = roles.id
+		WHERE user_roles.user_id = ? AND roles.app_id = ?
+	`, userID, appID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.ID, &role.AppID, &role.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GetRolePermissions returns every permission name granted to roleID.
+func (s *Storage) GetRolePermissions(ctx context.Context, roleID int64) ([]string, error) {
+	const op = "sqlite.GetRolePermissions"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT permissions.name
+		FROM permissions
+		JOIN role_permissions ON role_permissions.permission_id = permissions.id
+		WHERE role_permissions.role_id = ?
+	`, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/storage"
+)
+
+// SaveRefreshToken persists a freshly issued refresh token.
+func (s *Storage) SaveRefreshToken(ctx context.Context, rt models.RefreshToken) error {
+	const op = "sqlite.SaveRefreshToken"
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, app_id, token_hash, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, rt.UserID, rt.AppID, rt.TokenHash, rt.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetRefreshToken returns the refresh token stored under tokenHash.
+func (s *Storage) GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	const op = "sqlite.GetRefreshToken"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, user_id, app_id, token_hash, expires_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`, tokenHash)
+
+	var rt models.RefreshToken
+	if err := row.Scan(&rt.ID, &rt.UserID, &rt.AppID, &rt.TokenHash, &rt.ExpiresAt, &rt.RevokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RefreshToken{}, fmt.Errorf("%s: %w", op, storage.ErrRefreshTokenNotFound)
+		}
+
+		return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rt, nil
+}
+
+// RevokeRefreshToken marks the refresh token stored under tokenHash as
+// revoked, so it can no longer be exchanged for a new token pair.
+func (s *Storage) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	const op = "sqlite.RevokeRefreshToken"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE token_hash = ? AND revoked_at IS NULL
+	`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrRefreshTokenNotFound)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every still-valid refresh token for userID
+// within appID, used when a reused (already-rotated) refresh token
+// signals the whole family may be compromised.
+func (s *Storage) RevokeAllForUser(ctx context.Context, userID int64, appID int) error {
+	const op = "sqlite.RevokeAllForUser"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND app_id = ? AND revoked_at IS NULL
+	`, userID, appID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeAllForUserAllApps revokes every still-valid refresh token for
+// userID across every app, used when the user's password itself may be
+// compromised (e.g. a password reset) rather than just one app's session.
+func (s *Storage) RevokeAllForUserAllApps(ctx context.Context, userID int64) error {
+	const op = "sqlite.RevokeAllForUserAllApps"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND revoked_at IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
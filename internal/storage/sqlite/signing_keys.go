@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"auth_grpc/internal/lib/jwt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Storage wraps a SQLite connection and implements the storage interfaces
+// consumed by the auth and jwt packages.
+type Storage struct {
+	db *sql.DB
+}
+
+func New(storagePath string) (*Storage, error) {
+	const op = "sqlite.New"
+
+	db, err := sql.Open("sqlite3", storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{db: db}, nil
+}
+
+// SaveSigningKey persists a freshly generated key pair for appID.
+func (s *Storage) SaveSigningKey(ctx context.Context, appID int, key jwt.StoredSigningKey) error {
+	const op = "sqlite.SaveSigningKey"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		INSERT INTO signing_keys (app_id, kid, algorithm, private_pem, public_pem, not_before, not_after)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, appID, key.Kid, key.Algorithm, key.PrivatePEM, key.PublicPEM, key.NotBefore, key.NotAfter); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ActiveSigningKey returns the most recently activated key that is within
+// its validity window, i.e. the one Login should sign new tokens with.
+func (s *Storage) ActiveSigningKey(ctx context.Context, appID int) (jwt.StoredSigningKey, error) {
+	const op = "sqlite.ActiveSigningKey"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT kid, algorithm, private_pem, public_pem, not_before, not_after
+		FROM signing_keys
+		WHERE app_id = ? AND not_before <= ? AND not_after > ?
+		ORDER BY not_before DESC
+		LIMIT 1
+	`, appID, time.Now(), time.Now())
+
+	var key jwt.StoredSigningKey
+	if err := row.Scan(&key.Kid, &key.Algorithm, &key.PrivatePEM, &key.PublicPEM, &key.NotBefore, &key.NotAfter); err != nil {
+		return jwt.StoredSigningKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+// ValidVerificationKeys returns every key still inside its validity window,
+// i.e. every key a JWKS consumer should accept for appID.
+func (s *Storage) ValidVerificationKeys(ctx context.Context, appID int) ([]jwt.StoredSigningKey, error) {
+	const op = "sqlite.ValidVerificationKeys"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT kid, algorithm, private_pem, public_pem, not_before, not_after
+		FROM signing_keys
+		WHERE app_id = ? AND not_after > ?
+		ORDER BY not_before DESC
+	`, appID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []jwt.StoredSigningKey
+	for rows.Next() {
+		var key jwt.StoredSigningKey
+		if err := rows.Scan(&key.Kid, &key.Algorithm, &key.PrivatePEM, &key.PublicPEM, &key.NotBefore, &key.NotAfter); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
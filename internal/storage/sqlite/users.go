@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"auth_grpc/internal/domain/models"
+	"auth_grpc/internal/storage"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SaveUser persists a new user with the given password hash and returns
+// its generated ID.
+func (s *Storage) SaveUser(ctx context.Context, email string, passHash []byte, passHashAlgo string) (int64, error) {
+	const op = "sqlite.SaveUser"
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO users (email, pass_hash, pass_hash_algo) VALUES (?, ?, ?)
+	`, email, passHash, passHashAlgo)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetUser returns the user registered under email.
+func (s *Storage) GetUser(ctx context.Context, email string) (models.User, error) {
+	const op = "sqlite.GetUser"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, email, pass_hash, pass_hash_algo, email_verified FROM users WHERE email = ?
+	`, email)
+
+	var user models.User
+	if err := row.Scan(&user.ID, &user.Email, &user.PassHash, &user.PassHashAlgo, &user.EmailVerified); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// GetUserByID returns the user with the given ID.
+func (s *Storage) GetUserByID(ctx context.Context, userID int64) (models.User, error) {
+	const op = "sqlite.GetUserByID"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, email, pass_hash, pass_hash_algo, email_verified FROM users WHERE id = ?
+	`, userID)
+
+	var user models.User
+	if err := row.Scan(&user.ID, &user.Email, &user.PassHash, &user.PassHashAlgo, &user.EmailVerified); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// UpdatePasswordHash overwrites userID's stored password hash, used for
+// rehash-on-login and password reset.
+func (s *Storage) UpdatePasswordHash(ctx context.Context, userID int64, passHash []byte, passHashAlgo string) error {
+	const op = "sqlite.UpdatePasswordHash"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE users SET pass_hash = ?, pass_hash_algo = ? WHERE id = ?
+	`, passHash, passHashAlgo, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkEmailVerified flips the email_verified flag on for userID.
+func (s *Storage) MarkEmailVerified(ctx context.Context, userID int64) error {
+	const op = "sqlite.MarkEmailVerified"
+
+	_, err := s.db.ExecContext(ctx, `UPDATE users SET email_verified = TRUE WHERE id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
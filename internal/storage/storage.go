@@ -7,7 +7,11 @@ import "errors"
 // По этим ошибкам сервисный слой сможет понять, что конкретно пошло не так, и принимать соответствующие решения.
 // Они не должны зависеть от конкретной реализации хранилища (будь то SQLite, Postgres, MongoDB и т.п.), поэтому мы их разместили в общем пакете.
 var (
-	ErrUserExists   = errors.New("user already exists")
-	ErrUserNotFound = errors.New("user not found")
-	ErrAppNotFound  = errors.New("app not found")
+	ErrUserExists           = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrAppNotFound          = errors.New("app not found")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRoleExists           = errors.New("role already exists")
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrOneTimeTokenNotFound = errors.New("one-time token not found")
 )